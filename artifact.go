@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// WriteReport renders findings_list into the given format and writes it
+// to path. Supported formats: osv, sarif, csaf, csv.
+func WriteReport(format, path string, findings_list []Finding) error {
+	switch format {
+	case "osv":
+		return writeJSON(path, buildOsvReport(findings_list))
+	case "sarif":
+		return writeJSON(path, buildSarifReport(findings_list))
+	case "csaf":
+		return writeJSON(path, buildCsafReport(findings_list))
+	case "csv":
+		return writeCsvReport(path, findings_list)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+func writeJSON(path string, v interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// findingComponent pulls the component fields DefectDojo attaches to a
+// finding, if present.
+func findingComponent(finding Finding) (name, version string) {
+	return finding.ComponentName, finding.ComponentVersion
+}
+
+// --- OSV -------------------------------------------------------------
+
+type osv_entry struct {
+	ID               string                 `json:"id"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+	Affected         []osv_affected         `json:"affected,omitempty"`
+}
+
+type osv_affected struct {
+	Package osv_package `json:"package"`
+}
+
+type osv_package struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+func buildOsvReport(findings_list []Finding) []osv_entry {
+	var entries []osv_entry
+
+	for _, finding := range findings_list {
+		if finding.cve == "" && finding.ghsa == "" {
+			continue
+		}
+
+		entry := osv_entry{
+			ID: finding.ghsa,
+			DatabaseSpecific: map[string]interface{}{
+				"epss_score":      fmt.Sprintf("%.5f", finding.epss_score),
+				"epss_percentile": fmt.Sprintf("%.5f", finding.epss_percentile),
+			},
+		}
+		if entry.ID == "" {
+			entry.ID = finding.cve
+		} else if finding.cve != "" {
+			entry.Aliases = append(entry.Aliases, finding.cve)
+		}
+
+		if name, version := findingComponent(finding); name != "" {
+			entry.Affected = append(entry.Affected, osv_affected{Package: osv_package{Name: name, Version: version}})
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// --- SARIF -------------------------------------------------------------
+
+type sarif_log struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []sarif_run `json:"runs"`
+}
+
+type sarif_run struct {
+	Tool    sarif_tool     `json:"tool"`
+	Results []sarif_result `json:"results"`
+}
+
+type sarif_tool struct {
+	Driver sarif_driver `json:"driver"`
+}
+
+type sarif_driver struct {
+	Name string `json:"name"`
+}
+
+type sarif_result struct {
+	RuleID     string           `json:"ruleId"`
+	Message    sarif_message    `json:"message"`
+	Properties sarif_properties `json:"properties"`
+}
+
+type sarif_message struct {
+	Text string `json:"text"`
+}
+
+type sarif_properties struct {
+	SecuritySeverity string     `json:"security-severity,omitempty"`
+	Epss             sarif_epss `json:"epss"`
+}
+
+type sarif_epss struct {
+	Score      float64 `json:"score"`
+	Percentile float64 `json:"percentile"`
+}
+
+func buildSarifReport(findings_list []Finding) sarif_log {
+	run := sarif_run{Tool: sarif_tool{Driver: sarif_driver{Name: "dd-epss-enrich"}}}
+
+	for _, finding := range findings_list {
+		rule_id := finding.cve
+		if rule_id == "" {
+			rule_id = finding.ghsa
+		}
+		if rule_id == "" {
+			continue
+		}
+
+		result := sarif_result{
+			RuleID:  rule_id,
+			Message: sarif_message{Text: fmt.Sprintf("%s: EPSS score %.5f", rule_id, finding.epss_score)},
+			Properties: sarif_properties{
+				Epss: sarif_epss{Score: finding.epss_score, Percentile: finding.epss_percentile},
+			},
+		}
+		if finding.CvssV3Score != 0 {
+			result.Properties.SecuritySeverity = strconv.FormatFloat(finding.CvssV3Score, 'f', 1, 64)
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	return sarif_log{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarif_run{run},
+	}
+}
+
+// --- CSAF ----------------------------------------------------------
+
+type csaf_document struct {
+	Document struct {
+		Title       string `json:"title"`
+		Category    string `json:"category"`
+		CsafVersion string `json:"csaf_version"`
+	} `json:"document"`
+	Vulnerabilities []csaf_vulnerability `json:"vulnerabilities"`
+}
+
+type csaf_vulnerability struct {
+	CVE    string       `json:"cve,omitempty"`
+	IDs    []csaf_id    `json:"ids,omitempty"`
+	Scores []csaf_score `json:"scores,omitempty"`
+	Notes  []csaf_note  `json:"notes,omitempty"`
+}
+
+type csaf_id struct {
+	SystemName string `json:"system_name"`
+	Text       string `json:"text"`
+}
+
+type csaf_score struct {
+	CvssV3 map[string]interface{} `json:"cvss_v3,omitempty"`
+}
+
+type csaf_note struct {
+	Category string `json:"category"`
+	Text     string `json:"text"`
+}
+
+func buildCsafReport(findings_list []Finding) csaf_document {
+	var doc csaf_document
+	doc.Document.Title = "dd-epss-enrich vulnerability metadata report"
+	doc.Document.Category = "csaf_informational_advisory"
+	doc.Document.CsafVersion = "2.0"
+
+	for _, finding := range findings_list {
+		if finding.cve == "" && finding.ghsa == "" {
+			continue
+		}
+
+		vuln := csaf_vulnerability{
+			CVE: finding.cve,
+			Notes: []csaf_note{{
+				Category: "other",
+				Text:     fmt.Sprintf("epss_score=%.5f epss_percentile=%.5f", finding.epss_score, finding.epss_percentile),
+			}},
+		}
+		if finding.ghsa != "" {
+			vuln.IDs = append(vuln.IDs, csaf_id{SystemName: "GHSA", Text: finding.ghsa})
+		}
+		if finding.CvssV3Score != 0 {
+			vuln.Scores = append(vuln.Scores, csaf_score{
+				CvssV3: map[string]interface{}{"baseScore": finding.CvssV3Score},
+			})
+		}
+
+		doc.Vulnerabilities = append(doc.Vulnerabilities, vuln)
+	}
+
+	return doc
+}
+
+// --- CSV -------------------------------------------------------------
+
+func writeCsvReport(path string, findings_list []Finding) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write([]string{"finding_id", "cve", "ghsa", "epss_score", "epss_percentile"}); err != nil {
+		return err
+	}
+
+	for _, finding := range findings_list {
+		record := []string{
+			strconv.Itoa(finding.ID),
+			finding.cve,
+			finding.ghsa,
+			fmt.Sprintf("%.5f", finding.epss_score),
+			fmt.Sprintf("%.5f", finding.epss_percentile),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	//Foreign deps
+	"golang.org/x/time/rate"
+)
+
+const (
+	max_retries = 5
+	retry_base  = 500 * time.Millisecond
+)
+
+// Client talks to the DefectDojo API with a bounded worker pool, a rate
+// limiter, and retries on throttling/server errors.
+type Client struct {
+	http        *http.Client
+	base_url    string
+	auth_token  string
+	limiter     *rate.Limiter
+	concurrency int
+	dry_run     bool
+}
+
+// NewClient builds a Client for the DefectDojo instance described by
+// flags. rps bounds the request rate, concurrency bounds the worker pool
+// used by PatchFindings, and dry_run logs planned patches instead of
+// sending them.
+func NewClient(flags flags, rps float64, concurrency int, dry_run bool) *Client {
+	return &Client{
+		http:        &http.Client{},
+		base_url:    fmt.Sprintf("http://%v:%v", flags.ip, flags.port),
+		auth_token:  flags.authToken,
+		limiter:     rate.NewLimiter(rate.Limit(rps), 1),
+		concurrency: concurrency,
+		dry_run:     dry_run,
+	}
+}
+
+// do sends req, retrying on 429/5xx with exponential backoff and jitter.
+// The caller's context governs cancellation across all attempts.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req.Header.Set("Authorization", c.auth_token)
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt <= max_retries; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err = c.http.Do(req.WithContext(ctx))
+		if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return resp, nil
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if attempt == max_retries {
+			break
+		}
+
+		backoff := time.Duration(float64(retry_base) * math.Pow(2, float64(attempt)))
+		jitter := time.Duration(rand.Int63n(int64(backoff)))
+		select {
+		case <-time.After(backoff + jitter):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("request to %s failed after %d retries with status %d", req.URL, max_retries, resp.StatusCode)
+}
+
+/*
+FetchFindings fetches every active finding from DefectDojo, following the
+paginated `next` links rather than forcing a single page with an
+unbounded limit.
+*/
+func (c *Client) FetchFindings(ctx context.Context) ([]Finding, error) {
+	var findings []Finding
+	url := fmt.Sprintf("%s/api/v2/findings/?active=true&limit=100", c.base_url)
+
+	for url != "" {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.do(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		var page FindingsPage
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding findings page: %w", err)
+		}
+
+		findings = append(findings, page.Results...)
+		url = page.Next
+	}
+
+	return findings, nil
+}
+
+/*
+PatchFindings pushes only the fields that changed between originals and
+findings (same order, one enriched finding per original), fanning the
+work out across a bounded worker pool. Findings with no changed fields
+never hit the network.
+*/
+func (c *Client) PatchFindings(ctx context.Context, originals, findings []Finding) error {
+	type patch_job struct {
+		original Finding
+		updated  Finding
+	}
+
+	jobs := make(chan patch_job)
+	errs := make(chan error, len(findings))
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if err := c.patchOne(ctx, job.original, job.updated); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+feed:
+	for i, updated := range findings {
+		job := patch_job{original: originals[i], updated: updated}
+		select {
+		case jobs <- job:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (c *Client) patchOne(ctx context.Context, original, updated Finding) error {
+	diff := DiffFinding(original, updated)
+	if len(diff) == 0 {
+		fmt.Printf("Finding %d: no change, skipping\n", updated.ID)
+		return nil
+	}
+
+	if c.dry_run {
+		fmt.Printf("[dry-run] would PATCH finding %d with %v\n", updated.ID, diff)
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/api/v2/findings/%d", c.base_url, updated.ID)
+
+	payload, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("patching finding %d: %w", updated.ID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("patching finding %d failed with status code %d", updated.ID, resp.StatusCode)
+	}
+
+	fmt.Printf("Successfully updated finding with ID: %d\n", updated.ID)
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	//Foreign deps
+	"github.com/spf13/cobra"
+)
+
+const epss_date_layout = "2006-01-02"
+
+var (
+	backfill_from  string
+	backfill_to    string
+	backfill_store string
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Download dated EPSS snapshots and store per-CVE score history",
+	Run:   runBackfill,
+}
+
+func init() {
+	backfillCmd.Flags().StringVar(&backfill_from, "from", "", "First snapshot date to backfill (YYYY-MM-DD)")
+	backfillCmd.Flags().StringVar(&backfill_to, "to", "", "Last snapshot date to backfill (YYYY-MM-DD)")
+	backfillCmd.Flags().StringVar(&backfill_store, "store", "epss_history.db", "Path to the BoltDB history store")
+}
+
+func runBackfill(cmd *cobra.Command, args []string) {
+	from, err := time.Parse(epss_date_layout, backfill_from)
+	if err != nil {
+		log.Fatalf("invalid --from date: %v", err)
+	}
+
+	to, err := time.Parse(epss_date_layout, backfill_to)
+	if err != nil {
+		log.Fatalf("invalid --to date: %v", err)
+	}
+
+	if to.Before(from) {
+		log.Fatal("--to must not be before --from")
+	}
+
+	db, err := OpenHistoryStore(backfill_store)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		snapshot := date.Format(epss_date_layout)
+
+		fmt.Printf("Backfilling EPSS snapshot %s...\n", snapshot)
+		path, err := FetchEpssData(snapshot)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		epss_list, _, err := LoadEpssData(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, epss := range epss_list {
+			point := history_point{
+				Date:       snapshot,
+				Score:      epss.score,
+				Percentile: epss.percentile,
+			}
+			if err := AppendHistoryPoint(db, epss.cve, point); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		fmt.Printf("Stored %d CVE scores for %s\n", len(epss_list), snapshot)
+	}
+
+	fmt.Println("Backfill complete.")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	//Foreign deps
+	"github.com/spf13/cobra"
+)
+
+var (
+	enrich_enrichers     []string
+	enrich_concurrency   int
+	enrich_rate          float64
+	enrich_dry_run       bool
+	enrich_report_format string
+	enrich_report_output string
+	enrich_no_patch      bool
+)
+
+var enrichCmd = &cobra.Command{
+	Use:   "enrich",
+	Short: "Fetch vulnerability metadata and patch it onto active DefectDojo findings",
+	Run:   runEnrich,
+}
+
+func init() {
+	enrichCmd.Flags().StringSliceVar(&enrich_enrichers, "enrichers", []string{"epss"}, "Comma-separated enrichers to run (epss,kev,nvd,ghsa)")
+	enrichCmd.Flags().IntVar(&enrich_concurrency, "concurrency", 4, "Number of findings to patch concurrently")
+	enrichCmd.Flags().Float64Var(&enrich_rate, "rate", 5, "Maximum DefectDojo requests per second")
+	enrichCmd.Flags().BoolVar(&enrich_dry_run, "dry-run", false, "Log planned patches instead of sending them")
+	enrichCmd.Flags().StringVar(&enrich_report_format, "report", "", "Also write enriched findings to a local report (osv|csaf|sarif|csv)")
+	enrichCmd.Flags().StringVar(&enrich_report_output, "report-output", "", "Report file path (default report.<format>)")
+	enrichCmd.Flags().BoolVar(&enrich_no_patch, "no-patch", false, "Skip patching DefectDojo, e.g. when only --report output is wanted")
+}
+
+func runEnrich(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	client := NewClient(currentFlags(), enrich_rate, enrich_concurrency, enrich_dry_run)
+
+	enrichers, err := ResolveEnrichers(enrich_enrichers)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Starting enrichment...")
+
+	fetched, err := client.FetchFindings(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Fetched all active findings...")
+
+	findings_list := CreateFindingsRaw(fetched)
+	originals := append([]Finding(nil), findings_list...)
+	fmt.Printf("%v findings loaded...\n", len(findings_list))
+
+	for _, enricher := range enrichers {
+		fmt.Printf("Loading %s feed...\n", enricher.Name())
+		if err := enricher.Load(findings_list); err != nil {
+			log.Fatal(err)
+		}
+
+		for i := range findings_list {
+			enricher.Enrich(&findings_list[i])
+		}
+	}
+	fmt.Println("Enriched findings...")
+
+	if enrich_report_format != "" {
+		path := enrich_report_output
+		if path == "" {
+			path = fmt.Sprintf("report.%s", enrich_report_format)
+		}
+		if err := WriteReport(enrich_report_format, path, findings_list); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Wrote %s report to %s\n", enrich_report_format, path)
+	}
+
+	if enrich_no_patch {
+		return
+	}
+
+	if err := client.PatchFindings(ctx, originals, findings_list); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Findings patched.")
+}
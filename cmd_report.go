@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	//Foreign deps
+	"github.com/spf13/cobra"
+)
+
+var report_store string
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report 7d/30d EPSS score trend deltas for active findings",
+	Run:   runReport,
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&report_store, "store", "epss_history.db", "Path to the BoltDB history store populated by backfill")
+}
+
+// trend_delta summarizes how a CVE's EPSS score and percentile moved over
+// the last 7 and 30 days, as found in the history store. has_7d/has_30d
+// are false when no history point exists on or before that baseline
+// date, distinguishing "no movement" from "no baseline to compare".
+type trend_delta struct {
+	cve                  string
+	current              float64
+	delta_7d             float64
+	delta_30d            float64
+	has_7d               bool
+	has_30d              bool
+	current_percentile   float64
+	delta_percentile_7d  float64
+	delta_percentile_30d float64
+	crossed_50pct        bool
+}
+
+func runReport(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	client := NewClient(currentFlags(), 5, 1, false)
+
+	db, err := OpenHistoryStore(report_store)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	fetched, err := client.FetchFindings(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	findings_list := CreateFindingsRaw(fetched)
+
+	now := time.Now()
+
+	for _, finding := range findings_list {
+		points, err := HistoryFor(db, finding.cve)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(points) == 0 {
+			continue
+		}
+
+		current := points[len(points)-1]
+		delta := trend_delta{
+			cve:                finding.cve,
+			current:            current.Score,
+			current_percentile: current.Percentile,
+		}
+
+		if p := closestPoint(points, now.AddDate(0, 0, -7)); p != nil {
+			delta.has_7d = true
+			delta.delta_7d = current.Score - p.Score
+			delta.delta_percentile_7d = current.Percentile - p.Percentile
+		}
+		if p := closestPoint(points, now.AddDate(0, 0, -30)); p != nil {
+			delta.has_30d = true
+			delta.delta_30d = current.Score - p.Score
+			delta.delta_percentile_30d = current.Percentile - p.Percentile
+			delta.crossed_50pct = p.Percentile < 0.5 && current.Percentile >= 0.5
+		}
+
+		crossed_50pct := "n/a"
+		if delta.has_30d {
+			crossed_50pct = fmt.Sprintf("%v", delta.crossed_50pct)
+		}
+
+		fmt.Printf("%-16s score=%.5f (7d=%s 30d=%s)  percentile=%.5f (7d=%s 30d=%s)  crossed_50pct=%s\n",
+			delta.cve, delta.current, formatDelta(delta.has_7d, delta.delta_7d), formatDelta(delta.has_30d, delta.delta_30d),
+			delta.current_percentile, formatDelta(delta.has_7d, delta.delta_percentile_7d), formatDelta(delta.has_30d, delta.delta_percentile_30d), crossed_50pct)
+	}
+}
+
+// closestPoint returns the history point on or before target whose date
+// is closest to it, or nil if no such point exists (e.g. history starts
+// after target) — a missing baseline must not be reported as "no
+// movement".
+func closestPoint(points []history_point, target time.Time) *history_point {
+	var best *history_point
+	var best_diff time.Duration
+
+	for i := range points {
+		date, err := time.Parse(epss_date_layout, points[i].Date)
+		if err != nil {
+			continue
+		}
+		if date.After(target) {
+			continue
+		}
+
+		diff := target.Sub(date)
+		if best == nil || diff < best_diff {
+			best = &points[i]
+			best_diff = diff
+		}
+	}
+
+	return best
+}
+
+// formatDelta renders a trend delta, or "n/a" when no baseline point
+// was available to compute it.
+func formatDelta(ok bool, delta float64) string {
+	if !ok {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.5f", delta)
+}
@@ -0,0 +1,42 @@
+package main
+
+import "fmt"
+
+/*
+Enricher is implemented by each pluggable vulnerability-metadata source
+(EPSS, NVD CVSS, CISA KEV, GHSA, ...). Load fetches/builds whatever index
+the enricher needs to cover findings, which it receives so that sources
+queried per-vulnerability (like NVD) only fetch what's actually present
+instead of an entire feed. Enrich is then called once per finding to
+contribute fields to it.
+*/
+type Enricher interface {
+	Name() string
+	Load(findings []Finding) error
+	Enrich(f *Finding)
+}
+
+// enricher_registry maps the names accepted by --enrichers to constructors
+// for the matching Enricher implementation.
+var enricher_registry = map[string]func() Enricher{
+	"epss": func() Enricher { return &EpssEnricher{} },
+	"kev":  func() Enricher { return &KevEnricher{} },
+	"nvd":  func() Enricher { return &NvdEnricher{} },
+	"ghsa": func() Enricher { return &GhsaEnricher{} },
+}
+
+// ResolveEnrichers builds the Enricher set named by --enrichers, in the
+// order given.
+func ResolveEnrichers(names []string) ([]Enricher, error) {
+	var enrichers []Enricher
+
+	for _, name := range names {
+		factory, ok := enricher_registry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown enricher %q", name)
+		}
+		enrichers = append(enrichers, factory())
+	}
+
+	return enrichers, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import "fmt"
+
+// EpssEnricher wraps the existing EPSS fetch/load pipeline as a pluggable
+// Enricher, contributing epss_score and epss_percentile.
+type EpssEnricher struct {
+	index map[string]epss_data
+}
+
+func (e *EpssEnricher) Name() string { return "epss" }
+
+func (e *EpssEnricher) Load(findings []Finding) error {
+	path, err := FetchEpssData("current")
+	if err != nil {
+		return err
+	}
+
+	epss_list, _, err := LoadEpssData(path)
+	if err != nil {
+		return err
+	}
+
+	e.index = make(map[string]epss_data, len(epss_list))
+	for _, epss := range epss_list {
+		e.index[epss.cve] = epss
+	}
+
+	return nil
+}
+
+func (e *EpssEnricher) Enrich(f *Finding) {
+	epss, ok := e.index[f.cve]
+	if !ok {
+		return
+	}
+
+	f.epss_score = epss.score
+	f.epss_percentile = epss.percentile
+	f.EpssScore = fmt.Sprintf("%.5f", epss.score)
+	f.EpssPercentile = fmt.Sprintf("%.5f", epss.percentile)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"time"
+)
+
+const ghsa_advisory_url = "https://api.github.com/advisories"
+
+// ghsa_advisory holds the subset of a GitHub Security Advisory we index.
+type ghsa_advisory struct {
+	severity string
+	summary  string
+}
+
+// GhsaEnricher contributes data for findings whose only vulnerability_ids
+// entry is a GHSA ID (previously dropped entirely by CreateFindingsRaw's
+// CVE-only lookup).
+type GhsaEnricher struct {
+	index map[string]ghsa_advisory
+	token string
+}
+
+func (e *GhsaEnricher) Name() string { return "ghsa" }
+
+/*
+Load queries the GitHub Advisories API once per distinct GHSA ID present
+in findings, rather than paginating the entire advisory corpus (which
+exhausts GitHub's 60 req/hr unauthenticated cap within ~60 pages). A
+GITHUB_TOKEN environment variable, if set, is sent as a bearer token to
+raise that cap; either way, a 403 (rate limited) is retried with
+exponential backoff rather than failing the whole enrich run.
+*/
+func (e *GhsaEnricher) Load(findings []Finding) error {
+	e.token = os.Getenv("GITHUB_TOKEN")
+	e.index = make(map[string]ghsa_advisory)
+
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if f.ghsa == "" || seen[f.ghsa] {
+			continue
+		}
+		seen[f.ghsa] = true
+
+		advisory, ok, err := e.fetchAdvisory(f.ghsa)
+		if err != nil {
+			return err
+		}
+		if ok {
+			e.index[f.ghsa] = advisory
+		}
+	}
+
+	return nil
+}
+
+// fetchAdvisory looks up a single GHSA ID, retrying on 403 (GitHub's
+// unauthenticated/secondary rate limit) with exponential backoff. The
+// bool return is false for a 404 (unknown GHSA ID).
+func (e *GhsaEnricher) fetchAdvisory(ghsa_id string) (ghsa_advisory, bool, error) {
+	url := fmt.Sprintf("%s/%s", ghsa_advisory_url, ghsa_id)
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return ghsa_advisory{}, false, err
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if e.token != "" {
+			req.Header.Set("Authorization", "Bearer "+e.token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return ghsa_advisory{}, false, err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return ghsa_advisory{}, false, nil
+		}
+
+		if resp.StatusCode == http.StatusForbidden && attempt < max_retries {
+			resp.Body.Close()
+			backoff := time.Duration(float64(retry_base) * math.Pow(2, float64(attempt)))
+			time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff))))
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return ghsa_advisory{}, false, fmt.Errorf("fetching GHSA advisory %s: unexpected status code %d", ghsa_id, resp.StatusCode)
+		}
+
+		var advisory struct {
+			Severity string `json:"severity"`
+			Summary  string `json:"summary"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&advisory)
+		resp.Body.Close()
+		if err != nil {
+			return ghsa_advisory{}, false, fmt.Errorf("decoding GHSA advisory %s: %w", ghsa_id, err)
+		}
+
+		return ghsa_advisory{severity: advisory.Severity, summary: advisory.Summary}, true, nil
+	}
+}
+
+func (e *GhsaEnricher) Enrich(f *Finding) {
+	if f.ghsa == "" {
+		return
+	}
+
+	advisory, ok := e.index[f.ghsa]
+	if !ok {
+		return
+	}
+
+	f.GhsaID = f.ghsa
+	f.GhsaSeverity = advisory.severity
+}
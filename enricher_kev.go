@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const kev_feed_url = "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json"
+
+// kev_catalog is the subset of the CISA KEV catalog schema that we care
+// about.
+type kev_catalog struct {
+	Vulnerabilities []struct {
+		CveID     string `json:"cveID"`
+		DateAdded string `json:"dateAdded"`
+	} `json:"vulnerabilities"`
+}
+
+// KevEnricher flags findings whose CVE is in the CISA Known Exploited
+// Vulnerabilities catalog, contributing cisa_kev and kev_date_added.
+type KevEnricher struct {
+	index map[string]string // cve -> dateAdded
+}
+
+func (e *KevEnricher) Name() string { return "kev" }
+
+func (e *KevEnricher) Load(findings []Finding) error {
+	resp, err := http.Get(kev_feed_url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching KEV catalog: unexpected status code %d", resp.StatusCode)
+	}
+
+	var catalog kev_catalog
+	if err := json.NewDecoder(resp.Body).Decode(&catalog); err != nil {
+		return fmt.Errorf("decoding KEV catalog: %w", err)
+	}
+
+	e.index = make(map[string]string, len(catalog.Vulnerabilities))
+	for _, vuln := range catalog.Vulnerabilities {
+		e.index[vuln.CveID] = vuln.DateAdded
+	}
+
+	return nil
+}
+
+func (e *KevEnricher) Enrich(f *Finding) {
+	date_added, ok := e.index[f.cve]
+	if !ok {
+		return
+	}
+
+	f.CisaKev = true
+	f.KevDateAdded = date_added
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	//Foreign deps
+	"golang.org/x/time/rate"
+)
+
+const nvd_feed_url = "https://services.nvd.nist.gov/rest/json/cves/2.0"
+
+// NVD's documented request limits for the public CVE API: 5 requests per
+// rolling 30s window without an API key, 50 with one.
+const (
+	nvd_rate_unauthenticated = rate.Limit(5.0 / 30.0)
+	nvd_rate_authenticated   = rate.Limit(50.0 / 30.0)
+)
+
+// nvd_page is the subset of an NVD API 2.0 response page that we care
+// about. A cveId-scoped query returns at most one vulnerability.
+type nvd_page struct {
+	TotalResults    int `json:"totalResults"`
+	Vulnerabilities []struct {
+		Cve struct {
+			ID      string `json:"id"`
+			Metrics struct {
+				CvssMetricV31 []struct {
+					CvssData struct {
+						BaseScore float64 `json:"baseScore"`
+					} `json:"cvssData"`
+				} `json:"cvssMetricV31"`
+			} `json:"metrics"`
+		} `json:"cve"`
+	} `json:"vulnerabilities"`
+}
+
+// NvdEnricher contributes the CVSS v3.1 base score sourced from the NVD
+// API, looked up per-CVE rather than by walking the full corpus.
+type NvdEnricher struct {
+	index   map[string]float64
+	limiter *rate.Limiter
+	api_key string
+}
+
+func (e *NvdEnricher) Name() string { return "nvd" }
+
+/*
+Load queries the NVD API once per distinct CVE present in findings,
+rather than paginating the entire ~250k-CVE corpus. Requests are rate
+limited to NVD's documented 5 req/30s unauthenticated limit, or 50 req/30s
+when an NVD_API_KEY environment variable is set (sent as the apiKey
+header). A lookup failure for one CVE is logged and skipped rather than
+aborting the run, since at one request per CVE a single transient error
+is far more likely to occur than it was with the old whole-corpus
+pagination.
+*/
+func (e *NvdEnricher) Load(findings []Finding) error {
+	e.api_key = os.Getenv("NVD_API_KEY")
+
+	limit := nvd_rate_unauthenticated
+	if e.api_key != "" {
+		limit = nvd_rate_authenticated
+	}
+	e.limiter = rate.NewLimiter(limit, 1)
+
+	e.index = make(map[string]float64)
+
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if f.cve == "" || seen[f.cve] {
+			continue
+		}
+		seen[f.cve] = true
+
+		score, ok, err := e.fetchCvssScore(f.cve)
+		if err != nil {
+			log.Printf("nvd: skipping %s: %v", f.cve, err)
+			continue
+		}
+		if ok {
+			e.index[f.cve] = score
+		}
+	}
+
+	return nil
+}
+
+// fetchCvssScore looks up a single CVE's CVSS v3.1 base score. The bool
+// return is false when NVD has no v3.1 metrics for the CVE.
+func (e *NvdEnricher) fetchCvssScore(cve string) (float64, bool, error) {
+	if err := e.limiter.Wait(context.Background()); err != nil {
+		return 0, false, err
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s?cveId=%s", nvd_feed_url, cve), nil)
+	if err != nil {
+		return 0, false, err
+	}
+	if e.api_key != "" {
+		req.Header.Set("apiKey", e.api_key)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("fetching NVD metrics for %s: unexpected status code %d", cve, resp.StatusCode)
+	}
+
+	var page nvd_page
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return 0, false, fmt.Errorf("decoding NVD response for %s: %w", cve, err)
+	}
+
+	if len(page.Vulnerabilities) == 0 || len(page.Vulnerabilities[0].Cve.Metrics.CvssMetricV31) == 0 {
+		return 0, false, nil
+	}
+
+	return page.Vulnerabilities[0].Cve.Metrics.CvssMetricV31[0].CvssData.BaseScore, true, nil
+}
+
+func (e *NvdEnricher) Enrich(f *Finding) {
+	score, ok := e.index[f.cve]
+	if !ok {
+		return
+	}
+
+	f.CvssV3Score = score
+}
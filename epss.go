@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// epss_metadata is the "#model_version:...,score_date:..." line EPSS
+// puts on row 0 of every snapshot.
+type epss_metadata struct {
+	model_version string
+	score_date    string // YYYY-MM-DDTHH:MM:SS+0000
+}
+
+// epssCacheDir returns $XDG_CACHE_HOME/dd-epss-enrich, falling back to
+// ~/.cache/dd-epss-enrich, creating it if necessary.
+func epssCacheDir() (string, error) {
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".cache")
+	}
+	dir = filepath.Join(dir, "dd-epss-enrich")
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func epssCachePath(snapshot string) (string, error) {
+	dir, err := epssCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("epss_scores-%s.csv.gz", snapshot)), nil
+}
+
+/*
+FetchEpssData ensures the EPSS snapshot ("current" or "YYYY-MM-DD") is
+present in the cache directory, downloading it from
+https://epss.cyentia.com/epss_scores-<snapshot>.csv.gz only when missing.
+"current" is re-downloaded unless the cached copy's own score_date line
+already matches today, since dated snapshots never change once published
+but "current" does daily. Returns the path to the cached gzip file.
+*/
+func FetchEpssData(snapshot string) (string, error) {
+	path, err := epssCachePath(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	if snapshot != "current" {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	} else if fresh, err := isEpssCacheFresh(path); err != nil {
+		return "", err
+	} else if fresh {
+		fmt.Println("Cached EPSS snapshot is already today's, skipping download")
+		return path, nil
+	}
+
+	url := fmt.Sprintf("https://epss.cyentia.com/epss_scores-%s.csv.gz", snapshot)
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching EPSS snapshot %s: unexpected status code %d", snapshot, resp.StatusCode)
+	}
+
+	// Write to a temp file in the same directory and rename into place
+	// once fully downloaded, so a failed/interrupted download never
+	// leaves a bad file at path masquerading as a valid cache hit.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "epss_scores-*.csv.gz.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// isEpssCacheFresh reports whether the cached snapshot at path carries
+// today's score_date. A missing file is simply not fresh.
+func isEpssCacheFresh(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return false, err
+	}
+	defer gz.Close()
+
+	meta, err := readEpssMetadata(bufio.NewReader(gz))
+	if err != nil {
+		return false, err
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	return strings.HasPrefix(meta.score_date, today), nil
+}
+
+// readEpssMetadata parses the "#model_version:...,score_date:..." line
+// EPSS puts on row 0, leaving r positioned at the CSV header row.
+func readEpssMetadata(r *bufio.Reader) (epss_metadata, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return epss_metadata{}, err
+	}
+
+	var meta epss_metadata
+	line = strings.TrimPrefix(strings.TrimSpace(line), "#")
+	for _, field := range strings.Split(line, ",") {
+		kv := strings.SplitN(field, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "model_version":
+			meta.model_version = kv[1]
+		case "score_date":
+			meta.score_date = kv[1]
+		}
+	}
+
+	return meta, nil
+}
+
+/*
+LoadEpssData streams the cached gzip snapshot at path straight through
+gzip.NewReader into csv.Reader, never materializing the (hundreds-of-MB)
+decompressed CSV in memory. A malformed row is logged as a warning and
+skipped rather than aborting the whole load.
+*/
+func LoadEpssData(path string) ([]epss_data, epss_metadata, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, epss_metadata{}, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, epss_metadata{}, err
+	}
+	defer gz.Close()
+
+	br := bufio.NewReader(gz)
+	meta, err := readEpssMetadata(br)
+	if err != nil {
+		return nil, epss_metadata{}, err
+	}
+
+	r := csv.NewReader(br)
+
+	// First CSV record is the "cve,epss,percentile" header, not data.
+	if _, err := r.Read(); err != nil {
+		return nil, meta, fmt.Errorf("reading EPSS header row: %w", err)
+	}
+
+	var epss_list []epss_data
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("warning: skipping malformed EPSS row: %v", err)
+			continue
+		}
+		if len(record) < 3 {
+			log.Printf("warning: skipping malformed EPSS row: %v", record)
+			continue
+		}
+
+		score, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			log.Printf("warning: skipping EPSS row for %s: %v", record[0], err)
+			continue
+		}
+		percentile, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			log.Printf("warning: skipping EPSS row for %s: %v", record[0], err)
+			continue
+		}
+
+		epss_list = append(epss_list, epss_data{
+			cve:        record[0],
+			score:      score,
+			percentile: percentile,
+		})
+	}
+
+	return epss_list, meta, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+// VulnerabilityID is one entry of a finding's vulnerability_ids array.
+type VulnerabilityID struct {
+	VulnerabilityID string `json:"vulnerability_id"`
+}
+
+// Finding is the subset of the DefectDojo `/api/v2/findings/` finding
+// schema this tool reads and writes, plus the fields every enricher
+// contributes.
+type Finding struct {
+	ID               int               `json:"id"`
+	VulnerabilityIDs []VulnerabilityID `json:"vulnerability_ids"`
+	ComponentName    string            `json:"component_name,omitempty"`
+	ComponentVersion string            `json:"component_version,omitempty"`
+
+	EpssScore      string  `json:"epss_score,omitempty"`
+	EpssPercentile string  `json:"epss_percentile,omitempty"`
+	CvssV3Score    float64 `json:"cvssv3_score,omitempty"`
+	CisaKev        bool    `json:"cisa_kev,omitempty"`
+	KevDateAdded   string  `json:"kev_date_added,omitempty"`
+	GhsaID         string  `json:"ghsa_id,omitempty"`
+	GhsaSeverity   string  `json:"ghsa_severity,omitempty"`
+
+	// Derived by CreateFindingsRaw, not part of the DefectDojo schema.
+	cve             string
+	ghsa            string
+	epss_score      float64
+	epss_percentile float64
+}
+
+// FindingsPage is one page of the paginated `/api/v2/findings/` response.
+type FindingsPage struct {
+	Count    int       `json:"count"`
+	Next     string    `json:"next"`
+	Previous string    `json:"previous"`
+	Results  []Finding `json:"results"`
+}
+
+/*
+CreateFindingsRaw is a pure function over already-decoded findings: it
+scans *all* of each finding's vulnerability_ids for the first CVE- and
+GHSA-prefixed entry (rather than blindly trusting index 0 or 1), so a
+finding whose primary ID is a GHSA no longer loses its CVE, and a
+GHSA-only finding is preserved for the GHSA enricher instead of being
+silently dropped.
+*/
+func CreateFindingsRaw(findings []Finding) []Finding {
+	for i := range findings {
+		for _, vuln := range findings[i].VulnerabilityIDs {
+			switch {
+			case strings.HasPrefix(vuln.VulnerabilityID, "CVE-") && findings[i].cve == "":
+				findings[i].cve = vuln.VulnerabilityID
+			case strings.HasPrefix(vuln.VulnerabilityID, "GHSA-") && findings[i].ghsa == "":
+				findings[i].ghsa = vuln.VulnerabilityID
+			}
+		}
+	}
+
+	return findings
+}
+
+// DiffFinding returns the JSON-tagged fields that differ between original
+// and updated, keyed by their `json` tag — the minimal PATCH payload.
+func DiffFinding(original, updated Finding) map[string]interface{} {
+	diff := make(map[string]interface{})
+
+	t := reflect.TypeOf(original)
+	ov := reflect.ValueOf(original)
+	uv := reflect.ValueOf(updated)
+
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		of := ov.Field(i).Interface()
+		uf := uv.Field(i).Interface()
+		if !reflect.DeepEqual(of, uf) {
+			diff[name] = uf
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,113 @@
+package main
+
+import "testing"
+
+func TestCreateFindingsRaw(t *testing.T) {
+	cases := []struct {
+		name      string
+		vuln_ids  []string
+		want_cve  string
+		want_ghsa string
+	}{
+		{
+			name:      "cve at index 0",
+			vuln_ids:  []string{"CVE-2024-1234", "GHSA-xxxx-yyyy-zzzz"},
+			want_cve:  "CVE-2024-1234",
+			want_ghsa: "GHSA-xxxx-yyyy-zzzz",
+		},
+		{
+			name:      "ghsa at index 0, cve shifted to index 1",
+			vuln_ids:  []string{"GHSA-xxxx-yyyy-zzzz", "CVE-2024-1234"},
+			want_cve:  "CVE-2024-1234",
+			want_ghsa: "GHSA-xxxx-yyyy-zzzz",
+		},
+		{
+			name:      "ghsa-only finding keeps its ghsa instead of being dropped",
+			vuln_ids:  []string{"GHSA-xxxx-yyyy-zzzz"},
+			want_cve:  "",
+			want_ghsa: "GHSA-xxxx-yyyy-zzzz",
+		},
+		{
+			name:      "cve-only finding",
+			vuln_ids:  []string{"CVE-2024-1234"},
+			want_cve:  "CVE-2024-1234",
+			want_ghsa: "",
+		},
+		{
+			name:      "only the first cve and first ghsa are kept",
+			vuln_ids:  []string{"CVE-2024-0001", "CVE-2024-0002", "GHSA-aaaa-bbbb-cccc", "GHSA-dddd-eeee-ffff"},
+			want_cve:  "CVE-2024-0001",
+			want_ghsa: "GHSA-aaaa-bbbb-cccc",
+		},
+		{
+			name:      "no recognized prefixes",
+			vuln_ids:  []string{"OTHER-1"},
+			want_cve:  "",
+			want_ghsa: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var ids []VulnerabilityID
+			for _, id := range c.vuln_ids {
+				ids = append(ids, VulnerabilityID{VulnerabilityID: id})
+			}
+
+			findings := CreateFindingsRaw([]Finding{{VulnerabilityIDs: ids}})
+
+			if got := findings[0].cve; got != c.want_cve {
+				t.Errorf("cve = %q, want %q", got, c.want_cve)
+			}
+			if got := findings[0].ghsa; got != c.want_ghsa {
+				t.Errorf("ghsa = %q, want %q", got, c.want_ghsa)
+			}
+		})
+	}
+}
+
+func TestDiffFinding(t *testing.T) {
+	original := Finding{
+		ID:            42,
+		ComponentName: "libfoo",
+		EpssScore:     "0.10000",
+		CisaKev:       false,
+	}
+
+	t.Run("no changes yields an empty diff", func(t *testing.T) {
+		if diff := DiffFinding(original, original); len(diff) != 0 {
+			t.Errorf("diff = %v, want empty", diff)
+		}
+	})
+
+	t.Run("only changed fields appear in the diff", func(t *testing.T) {
+		updated := original
+		updated.EpssScore = "0.90000"
+		updated.CisaKev = true
+
+		diff := DiffFinding(original, updated)
+
+		want := map[string]interface{}{
+			"epss_score": "0.90000",
+			"cisa_kev":   true,
+		}
+		if len(diff) != len(want) {
+			t.Fatalf("diff = %v, want %v", diff, want)
+		}
+		for k, v := range want {
+			if diff[k] != v {
+				t.Errorf("diff[%q] = %v, want %v", k, diff[k], v)
+			}
+		}
+	})
+
+	t.Run("unexported fields are never included", func(t *testing.T) {
+		updated := original
+		updated.cve = "CVE-2024-1234"
+
+		diff := DiffFinding(original, updated)
+		if len(diff) != 0 {
+			t.Errorf("diff = %v, want empty (unexported fields have no json tag)", diff)
+		}
+	})
+}
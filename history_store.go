@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	//Foreign deps
+	bolt "go.etcd.io/bbolt"
+)
+
+var history_bucket = []byte("epss_history")
+
+// history_point is one dated EPSS observation for a CVE, as stored in the
+// backfill database and consumed by the report subcommand.
+type history_point struct {
+	Date       string  `json:"date"`
+	Score      float64 `json:"score"`
+	Percentile float64 `json:"percentile"`
+}
+
+// OpenHistoryStore opens (creating if necessary) the BoltDB file used to
+// keep per-CVE EPSS score history.
+func OpenHistoryStore(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(history_bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// AppendHistoryPoint records a dated EPSS observation for cve, appending it
+// to whatever history is already stored rather than overwriting it.
+func AppendHistoryPoint(db *bolt.DB, cve string, point history_point) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(history_bucket)
+
+		var points []history_point
+		if raw := b.Get([]byte(cve)); raw != nil {
+			if err := json.Unmarshal(raw, &points); err != nil {
+				return err
+			}
+		}
+
+		points = append(points, point)
+
+		raw, err := json.Marshal(points)
+		if err != nil {
+			return err
+		}
+
+		return b.Put([]byte(cve), raw)
+	})
+}
+
+// HistoryFor returns the recorded EPSS history for cve, oldest first.
+func HistoryFor(db *bolt.DB, cve string) ([]history_point, error) {
+	var points []history_point
+
+	err := db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(history_bucket)
+		raw := b.Get([]byte(cve))
+		if raw == nil {
+			return nil
+		}
+		return json.Unmarshal(raw, &points)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading history for %s: %w", cve, err)
+	}
+
+	return points, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	//Foreign deps
+	"github.com/spf13/cobra"
+)
+
+// Shared DefectDojo connection flags, populated by rootCmd's persistent flags
+// and read by every subcommand via currentFlags().
+var (
+	authToken string
+	ddUrl     string
+	ddPort    int
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "dd-epss-enrich",
+	Short: "Enrich DefectDojo findings with EPSS (and friends) vulnerability metadata",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&authToken, "token", "t", "", "DefectDojo authorization token")
+	rootCmd.PersistentFlags().StringVarP(&ddUrl, "url", "u", "localhost", "DefectDojo host")
+	rootCmd.PersistentFlags().IntVarP(&ddPort, "port", "p", 8080, "DefectDojo port")
+
+	rootCmd.AddCommand(enrichCmd)
+	rootCmd.AddCommand(backfillCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+// currentFlags bundles the persistent connection flags into the flags
+// struct that the defectdojo helpers expect.
+func currentFlags() flags {
+	return flags{
+		authToken: authToken,
+		ip:        ddUrl,
+		port:      ddPort,
+	}
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,14 @@
+package main
+
+type epss_data struct {
+	cve        string
+	score      float64
+	percentile float64
+}
+
+// Used so flags can be passed to functions easier
+type flags struct {
+	authToken string
+	ip        string
+	port      int
+}